@@ -0,0 +1,599 @@
+/*
+Copyright (c) the purl authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package packageurl
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FromNpmSpec converts an npm package spec such as "lodash@4.17.21" or
+// "@scope/name@1.2.3" into a pkg:npm PackageURL. The scope, including its
+// leading '@', becomes the namespace. The version is optional.
+func FromNpmSpec(spec string) (PackageURL, error) {
+	rest := strings.TrimSpace(spec)
+	if rest == "" {
+		return PackageURL{}, fmt.Errorf("npm spec is empty")
+	}
+
+	var namespace string
+	if strings.HasPrefix(rest, "@") {
+		// the leading '@' belongs to the scope, so it must not be taken as
+		// the version separator.
+		scope, remainder, ok := strings.Cut(rest, "/")
+		if !ok || scope == "@" {
+			return PackageURL{}, fmt.Errorf("npm spec has an invalid scope: %q", spec)
+		}
+		namespace, rest = scope, remainder
+	}
+
+	name, version, hasVersion := strings.Cut(rest, "@")
+	if name == "" {
+		return PackageURL{}, fmt.Errorf("npm spec is missing name: %q", spec)
+	}
+	if strings.Contains(name, "/") {
+		return PackageURL{}, fmt.Errorf("npm spec has an invalid name: %q", spec)
+	}
+	if hasVersion && version == "" {
+		return PackageURL{}, fmt.Errorf("npm spec has an empty version: %q", spec)
+	}
+
+	p := PackageURL{
+		Type:      TypeNPM,
+		Namespace: namespace,
+		Name:      name,
+		Version:   version,
+	}
+	err := p.Normalize()
+	return p, err
+}
+
+// GoImportPath returns the import path of the Go package p refers to: the
+// module path, made of the namespace and name, followed by the subpath
+// pointing at a package within the module, e.g.
+// "pkg:golang/github.com/foo/bar@v1.2.0#internal/baz" gives
+// "github.com/foo/bar/internal/baz". It returns "" for other purl types.
+//
+// As Normalize lowercases golang namespaces and names, the import path of a
+// normalized purl is lowercase too.
+func (p PackageURL) GoImportPath() string {
+	if p.Type != TypeGolang {
+		return ""
+	}
+	parts := make([]string, 0, 3)
+	for _, part := range []string{p.Namespace, p.Name, p.Subpath} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// goMajorSuffixPattern matches the major version suffix of a Go module path,
+// such as the "/v2" of "github.com/foo/bar/v2", or the ".v3" of
+// "gopkg.in/yaml.v3".
+var goMajorSuffixPattern = regexp.MustCompile(`[/.]v([0-9]+)$`)
+
+// goMajorVersionPattern matches the major version of a Go module version, such
+// as the "2" of "v2.1.0".
+var goMajorVersionPattern = regexp.MustCompile(`^v([0-9]+)\.`)
+
+// GoMajorVersion returns the major version of the Go module p refers to. The
+// major version suffix of the module path, such as the "/v2" of
+// "pkg:golang/github.com/foo/bar/v2@v2.1.0", is part of the namespace and
+// name, as in Go, rather than of the version. It gives the major version if
+// present; otherwise the major version of p's version is returned, e.g. 0 for
+// "v0.3.1" or 2 for "v2.0.0+incompatible". It returns false if p isn't a
+// golang purl or if the major version can't be told.
+func (p PackageURL) GoMajorVersion() (int, bool) {
+	if p.Type != TypeGolang {
+		return 0, false
+	}
+	match := goMajorSuffixPattern.FindStringSubmatch("/" + p.Name)
+	if match == nil {
+		match = goMajorVersionPattern.FindStringSubmatch(p.Version)
+	}
+	if match == nil {
+		return 0, false
+	}
+	major, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+	return major, true
+}
+
+// FromGoImportPath converts the import path of a Go package within the module
+// modulePath into a pkg:golang PackageURL. It is the inverse of GoImportPath:
+// the module path maps to the namespace and name, and the rest of the import
+// path, if any, to the subpath. The version is optional.
+func FromGoImportPath(modulePath, importPath, version string) (PackageURL, error) {
+	modulePath = strings.Trim(modulePath, "/")
+	if modulePath == "" {
+		return PackageURL{}, fmt.Errorf("go module path is empty")
+	}
+	if importPath != modulePath && !strings.HasPrefix(importPath, modulePath+"/") {
+		return PackageURL{}, fmt.Errorf("go import path %q is not within module %q", importPath, modulePath)
+	}
+	subpath := strings.TrimPrefix(importPath[len(modulePath):], "/")
+
+	var namespace, name string
+	if sep := strings.LastIndex(modulePath, "/"); sep != -1 {
+		namespace, name = modulePath[:sep], modulePath[sep+1:]
+	} else {
+		name = modulePath
+	}
+	p := PackageURL{
+		Type:      TypeGolang,
+		Namespace: namespace,
+		Name:      name,
+		Version:   version,
+		Subpath:   subpath,
+	}
+	err := p.Normalize()
+	return p, err
+}
+
+// NativeCoordinate returns the identifier of p in the idiom of its ecosystem:
+//
+//   - maven: "group:artifact:version", e.g. "org.apache.commons:commons-io:2.11.0"
+//   - npm: "name@version" or "@scope/name@version", as accepted by FromNpmSpec
+//   - golang: "module@version", e.g. "github.com/foo/bar@v1.2.0"
+//   - pypi: "name==version", as in a requirements file
+//
+// The version part is omitted if p has no version. Qualifiers and the subpath
+// are dropped. It returns an error for other purl types.
+func (p PackageURL) NativeCoordinate() (string, error) {
+	if p.Name == "" {
+		return "", fmt.Errorf("purl is missing name")
+	}
+	var coordinate, sep string
+	switch p.Type {
+	case TypeMaven:
+		if p.Namespace == "" {
+			return "", fmt.Errorf("maven purl is missing group namespace")
+		}
+		coordinate, sep = p.Namespace+":"+p.Name, ":"
+	case TypeNPM, TypeGolang:
+		coordinate, sep = p.Name, "@"
+		if p.Namespace != "" {
+			coordinate = p.Namespace + "/" + p.Name
+		}
+	case TypePyPi:
+		coordinate, sep = p.Name, "=="
+	default:
+		return "", fmt.Errorf("native coordinate is not supported for type %q", p.Type)
+	}
+	if p.Version != "" {
+		coordinate += sep + p.Version
+	}
+	return coordinate, nil
+}
+
+// GitHubURL returns the URL of the GitHub repository p refers to, such as
+// "https://github.com/owner/repo/tree/v1.0.0" for
+// "pkg:github/owner/repo@v1.0.0". Without a version, the URL of the repository
+// root is returned.
+func (p PackageURL) GitHubURL() (string, error) {
+	return p.repositoryURL(TypeGithub, "github.com", "tree")
+}
+
+// FromGitHubURL converts the URL of a GitHub repository, such as
+// "https://github.com/owner/repo", into a pkg:github PackageURL. A ref given
+// as "/tree/<ref>" or "/releases/tag/<ref>" becomes the version. As a path
+// within the repository can't be told apart from a ref containing '/', all of
+// the path after "/tree/" is taken as the ref.
+func FromGitHubURL(u string) (PackageURL, error) {
+	return fromRepositoryURL(u, TypeGithub, "github.com", "tree", "releases/tag")
+}
+
+// BitbucketURL returns the URL of the Bitbucket repository p refers to, such as
+// "https://bitbucket.org/owner/repo/src/v1.0.0" for
+// "pkg:bitbucket/owner/repo@v1.0.0". Without a version, the URL of the
+// repository root is returned.
+func (p PackageURL) BitbucketURL() (string, error) {
+	return p.repositoryURL(TypeBitbucket, "bitbucket.org", "src")
+}
+
+// FromBitbucketURL converts the URL of a Bitbucket repository, such as
+// "https://bitbucket.org/owner/repo", into a pkg:bitbucket PackageURL. A ref
+// given as "/src/<ref>" becomes the version. As for FromGitHubURL, all of the
+// path after "/src/" is taken as the ref.
+func FromBitbucketURL(u string) (PackageURL, error) {
+	return fromRepositoryURL(u, TypeBitbucket, "bitbucket.org", "src")
+}
+
+// repositoryURL returns the URL of the repository p refers to on host, with
+// the version given under refPath.
+func (p PackageURL) repositoryURL(purlType, host, refPath string) (string, error) {
+	if p.Type != purlType {
+		return "", fmt.Errorf("purl type is not %s: %q", purlType, p.Type)
+	}
+	if p.Namespace == "" || strings.Contains(p.Namespace, "/") {
+		return "", fmt.Errorf("%s purl must have a single owner namespace: %q", purlType, p.Namespace)
+	}
+	u := "https://" + host + "/" + url.PathEscape(p.Namespace) + "/" + url.PathEscape(p.Name)
+	if p.Version == "" {
+		return u, nil
+	}
+	ref := strings.Split(p.Version, "/")
+	for i, segment := range ref {
+		ref[i] = url.PathEscape(segment)
+	}
+	return u + "/" + refPath + "/" + strings.Join(ref, "/"), nil
+}
+
+// fromRepositoryURL converts the URL of a repository on host into a purl of
+// the given type. A ref given under one of refPaths becomes the version.
+func fromRepositoryURL(rawURL, purlType, host string, refPaths ...string) (PackageURL, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return PackageURL{}, fmt.Errorf("invalid %s URL: %w", purlType, err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" || strings.TrimPrefix(strings.ToLower(u.Host), "www.") != host {
+		return PackageURL{}, fmt.Errorf("not a %s URL: %q", host, rawURL)
+	}
+	owner, rest, _ := strings.Cut(strings.Trim(u.Path, "/"), "/")
+	repo, rest, _ := strings.Cut(rest, "/")
+	repo = strings.TrimSuffix(repo, ".git")
+	if owner == "" || repo == "" {
+		return PackageURL{}, fmt.Errorf("%s URL is missing owner or repository: %q", host, rawURL)
+	}
+
+	var version string
+	if rest != "" {
+		for _, refPath := range refPaths {
+			if ref := strings.TrimPrefix(rest, refPath+"/"); ref != rest {
+				version = ref
+				break
+			}
+		}
+		if version == "" {
+			return PackageURL{}, fmt.Errorf("%s URL has an unsupported path: %q", host, rawURL)
+		}
+	}
+
+	p := PackageURL{
+		Type:      purlType,
+		Namespace: owner,
+		Name:      repo,
+		Version:   version,
+	}
+	err = p.Normalize()
+	return p, err
+}
+
+// SPDXExternalRef is an entry of the externalRefs of a package in an SPDX
+// document. A purl is carried with the PACKAGE-MANAGER category, the purl type
+// and the purl string as its locator.
+// See https://spdx.github.io/spdx-spec/v2.3/external-repository-identifiers/#f35-purl
+type SPDXExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+// ToSPDXExternalRef returns an SPDX external reference with category
+// PACKAGE-MANAGER, type purl, and the purl string of p as its locator.
+func (p PackageURL) ToSPDXExternalRef() SPDXExternalRef {
+	return SPDXExternalRef{
+		ReferenceCategory: "PACKAGE-MANAGER",
+		ReferenceType:     "purl",
+		ReferenceLocator:  p.ToString(),
+	}
+}
+
+// FromSPDXExternalRef parses the purl carried by an SPDX external reference.
+// It returns an error if ref isn't a purl reference. Both the
+// "PACKAGE-MANAGER" and "PACKAGE_MANAGER" spellings of the category, used by
+// different SPDX versions, are accepted.
+func FromSPDXExternalRef(ref SPDXExternalRef) (PackageURL, error) {
+	if ref.ReferenceCategory != "PACKAGE-MANAGER" && ref.ReferenceCategory != "PACKAGE_MANAGER" {
+		return PackageURL{}, fmt.Errorf("SPDX external reference category is not PACKAGE-MANAGER: %q", ref.ReferenceCategory)
+	}
+	if ref.ReferenceType != "purl" {
+		return PackageURL{}, fmt.Errorf("SPDX external reference type is not purl: %q", ref.ReferenceType)
+	}
+	return FromString(ref.ReferenceLocator)
+}
+
+// dockerHubRegistry is the registry used by image references which don't name
+// one explicitly.
+const dockerHubRegistry = "docker.io"
+
+// FromImageReference converts a Docker/OCI image reference such as
+// "docker.io/library/nginx:1.25@sha256:..." into a pkg:docker PackageURL.
+//
+// The repository path maps to the namespace and name, the digest to the
+// version and the tag to the "tag" qualifier. A registry other than Docker Hub
+// is kept in the "repository_url" qualifier. Single-segment Docker Hub images
+// such as "nginx" get the implicit "library" namespace.
+func FromImageReference(ref string) (PackageURL, error) {
+	rest := strings.TrimSpace(ref)
+	if rest == "" {
+		return PackageURL{}, fmt.Errorf("image reference is empty")
+	}
+
+	rest, digest, hasDigest := strings.Cut(rest, "@")
+	if hasDigest && digest == "" {
+		return PackageURL{}, fmt.Errorf("image reference has an empty digest: %q", ref)
+	}
+
+	var tag string
+	// a ':' after the last '/' separates the tag. Any earlier ':' is part of
+	// the registry host, e.g. "localhost:5000/app".
+	if sep := strings.LastIndex(rest, ":"); sep > strings.LastIndex(rest, "/") {
+		rest, tag = rest[:sep], rest[sep+1:]
+		if tag == "" {
+			return PackageURL{}, fmt.Errorf("image reference has an empty tag: %q", ref)
+		}
+	}
+
+	registry := dockerHubRegistry
+	segments := strings.Split(rest, "/")
+	if len(segments) > 1 && isRegistryHost(segments[0]) {
+		registry, segments = segments[0], segments[1:]
+	}
+	if registry == "index.docker.io" || registry == "registry-1.docker.io" {
+		registry = dockerHubRegistry
+	}
+	for _, segment := range segments {
+		if segment == "" {
+			return PackageURL{}, fmt.Errorf("image reference has an empty path segment: %q", ref)
+		}
+		if segment != strings.ToLower(segment) {
+			return PackageURL{}, fmt.Errorf("image reference repository must be lowercase: %q", ref)
+		}
+	}
+	if namespace, ok := DefaultNamespace(TypeDocker); ok && registry == dockerHubRegistry && len(segments) == 1 {
+		segments = append([]string{namespace}, segments...)
+	}
+
+	qualifiers := map[string]string{}
+	if registry != dockerHubRegistry {
+		qualifiers["repository_url"] = registry
+	}
+	if tag != "" {
+		qualifiers["tag"] = tag
+	}
+
+	p := PackageURL{
+		Type:       TypeDocker,
+		Namespace:  strings.Join(segments[:len(segments)-1], "/"),
+		Name:       segments[len(segments)-1],
+		Version:    digest,
+		Qualifiers: QualifiersFromMap(qualifiers),
+	}
+	err := p.Normalize()
+	return p, err
+}
+
+// digestPattern describes an OCI content digest such as "sha256:0d17b565c37b".
+var digestPattern = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]+$`)
+
+// ContainerReference is the structured form of a container image reference.
+type ContainerReference struct {
+	// Registry is the registry host, such as "docker.io" or "gcr.io".
+	Registry string
+	// Repository is the repository path within the registry, such as
+	// "library/nginx".
+	Repository string
+	// Tag is the image tag, such as "1.25". It may be empty if Digest is set.
+	Tag string
+	// Digest is the image content digest, such as "sha256:0d17b565c37b". It
+	// may be empty if Tag is set.
+	Digest string
+}
+
+// ContainerRef returns the container image reference of a pkg:docker or
+// pkg:oci purl.
+//
+// The version holds either a tag or a digest, which is told apart by its
+// form, and the other one may be given by the "tag" or "digest" qualifier.
+// The registry is taken from the "repository_url" qualifier and defaults to
+// "docker.io" for pkg:docker. An error is returned for other types, or if
+// neither a tag nor a digest is present.
+func (p PackageURL) ContainerRef() (ContainerReference, error) {
+	if p.Type != TypeDocker && p.Type != TypeOCI {
+		return ContainerReference{}, fmt.Errorf("purl type %q is not a container type", p.Type)
+	}
+
+	var ref ContainerReference
+	if digestPattern.MatchString(p.Version) {
+		ref.Digest = p.Version
+	} else {
+		ref.Tag = p.Version
+	}
+	if tag, ok := p.Qualifiers.get("tag"); ok && ref.Tag == "" {
+		ref.Tag = tag
+	}
+	if digest, ok := p.Qualifiers.get("digest"); ok && ref.Digest == "" {
+		ref.Digest = digest
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		return ContainerReference{}, fmt.Errorf("purl has neither a tag nor a digest")
+	}
+	if ref.Digest != "" && !digestPattern.MatchString(ref.Digest) {
+		return ContainerReference{}, fmt.Errorf("invalid digest: %q", ref.Digest)
+	}
+
+	ref.Repository = strings.Trim(p.Namespace+"/"+p.Name, "/")
+	repositoryURL, _ := p.Qualifiers.get("repository_url")
+	repositoryURL = strings.TrimPrefix(strings.TrimPrefix(repositoryURL, "https://"), "http://")
+	registry, repository, _ := strings.Cut(strings.Trim(repositoryURL, "/"), "/")
+	switch {
+	case registry == "" && p.Type == TypeDocker:
+		ref.Registry = dockerHubRegistry
+	case repository == "":
+		ref.Registry = registry
+	default:
+		// the OCI repository_url may include the repository, with or without
+		// the name.
+		ref.Registry = registry
+		ref.Repository = repository
+		if !strings.HasSuffix("/"+repository, "/"+p.Name) {
+			ref.Repository += "/" + p.Name
+		}
+	}
+	return ref, nil
+}
+
+// isRegistryHost reports whether the first segment of an image reference
+// names a registry rather than a repository path component.
+func isRegistryHost(segment string) bool {
+	return segment == "localhost" || strings.ContainsAny(segment, ".:")
+}
+
+// cpePrefix is the prefix of a CPE 2.3 formatted string.
+const cpePrefix = "cpe:2.3:"
+
+// cpeComponentCount is the number of colon-separated components in a CPE 2.3
+// formatted string, including the "cpe" and "2.3" prefix components.
+const cpeComponentCount = 13
+
+// ToCPE returns a best-effort CPE 2.3 formatted string for p, of the form
+// "cpe:2.3:a:vendor:product:version:*:*:*:*:*:*:*".
+//
+// The namespace maps to the vendor, the name to the product and the version to
+// the version. The conversion is lossy: the type, qualifiers and subpath are
+// dropped, values are lowercased and whitespace is replaced by '_', as is
+// customary for CPE names. An empty namespace or version is written as the
+// "*" (ANY) value.
+func (p PackageURL) ToCPE() (string, error) {
+	if p.Name == "" {
+		return "", fmt.Errorf("purl is missing name")
+	}
+	components := []string{
+		"a",
+		cpeEscape(p.Namespace),
+		cpeEscape(p.Name),
+		cpeEscape(p.Version),
+	}
+	for len(components) < cpeComponentCount-2 {
+		components = append(components, "*")
+	}
+	return cpePrefix + strings.Join(components, ":"), nil
+}
+
+// FromCPE converts a CPE 2.3 formatted string into a pkg:generic PackageURL.
+//
+// The vendor maps to the namespace, the product to the name and the version to
+// the version. All other CPE components are dropped. The "*" (ANY) and "-"
+// (NA) values are treated as empty.
+func FromCPE(cpe string) (PackageURL, error) {
+	if !strings.HasPrefix(strings.ToLower(cpe), cpePrefix) {
+		return PackageURL{}, fmt.Errorf("CPE is not a CPE 2.3 formatted string: %q", cpe)
+	}
+	components := splitCPE(cpe)
+	if len(components) != cpeComponentCount {
+		return PackageURL{}, fmt.Errorf("CPE must have %d components, got %d: %q", cpeComponentCount, len(components), cpe)
+	}
+
+	var values [3]string
+	for i, component := range components[3:6] {
+		value, err := cpeUnescape(component)
+		if err != nil {
+			return PackageURL{}, fmt.Errorf("invalid CPE %q: %w", cpe, err)
+		}
+		values[i] = value
+	}
+	if values[1] == "" {
+		return PackageURL{}, fmt.Errorf("CPE is missing product: %q", cpe)
+	}
+
+	p := PackageURL{
+		Type:      TypeGeneric,
+		Namespace: values[0],
+		Name:      values[1],
+		Version:   values[2],
+	}
+	err := p.Normalize()
+	return p, err
+}
+
+// cpeEscape converts s into a CPE 2.3 formatted string value, quoting every
+// character that isn't alphanumeric, '_', '-' or '.' with a backslash.
+func cpeEscape(s string) string {
+	if s == "" {
+		return "*"
+	}
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsSpace(r):
+			b.WriteByte('_')
+		case r < 0x80 && (unicode.IsLetter(r) || unicode.IsDigit(r)), r == '_', r == '-', r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// cpeUnescape reverses cpeEscape. The logical values "*" and "-" become the
+// empty string. Unquoted wildcards are rejected since they can't be
+// represented in a purl.
+func cpeUnescape(s string) (string, error) {
+	if s == "*" || s == "-" {
+		return "", nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			if i+1 == len(s) {
+				return "", fmt.Errorf("dangling escape in %q", s)
+			}
+			i++
+			b.WriteByte(s[i])
+		case '*', '?':
+			return "", fmt.Errorf("unsupported wildcard in %q", s)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), nil
+}
+
+// splitCPE splits a CPE formatted string on every ':' that isn't quoted by a
+// backslash.
+func splitCPE(s string) []string {
+	var components []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ':':
+			components = append(components, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(components, s[start:])
+}
@@ -0,0 +1,573 @@
+/*
+Copyright (c) the purl authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package packageurl_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+func TestFromNpmSpec(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    string
+		want    packageurl.PackageURL
+		wantErr bool
+	}{{
+		name: "unscoped with version",
+		spec: "lodash@4.17.21",
+		want: packageurl.PackageURL{
+			Type:       "npm",
+			Name:       "lodash",
+			Version:    "4.17.21",
+			Qualifiers: packageurl.Qualifiers{},
+		},
+	}, {
+		name: "scoped with version",
+		spec: "@angular/core@12.3.1",
+		want: packageurl.PackageURL{
+			Type:       "npm",
+			Namespace:  "@angular",
+			Name:       "core",
+			Version:    "12.3.1",
+			Qualifiers: packageurl.Qualifiers{},
+		},
+	}, {
+		name: "unscoped without version",
+		spec: "lodash",
+		want: packageurl.PackageURL{
+			Type:       "npm",
+			Name:       "lodash",
+			Qualifiers: packageurl.Qualifiers{},
+		},
+	}, {
+		name: "scoped without version",
+		spec: "@babel/core",
+		want: packageurl.PackageURL{
+			Type:       "npm",
+			Namespace:  "@babel",
+			Name:       "core",
+			Qualifiers: packageurl.Qualifiers{},
+		},
+	}, {
+		name:    "empty spec",
+		spec:    "",
+		wantErr: true,
+	}, {
+		name:    "scope without name",
+		spec:    "@angular",
+		wantErr: true,
+	}, {
+		name:    "empty scope",
+		spec:    "@/core@1.0.0",
+		wantErr: true,
+	}, {
+		name:    "empty version",
+		spec:    "lodash@",
+		wantErr: true,
+	}}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := packageurl.FromNpmSpec(testCase.spec)
+			if testCase.wantErr {
+				if err == nil {
+					t.Fatalf("FromNpmSpec(%q): want error, got %#v", testCase.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FromNpmSpec(%q): unexpected error: %v", testCase.spec, err)
+			}
+			if !reflect.DeepEqual(testCase.want, got) {
+				t.Fatalf("FromNpmSpec(%q):\nwant %#v\ngot %#v", testCase.spec, testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestFromImageReference(t *testing.T) {
+	testCases := []struct {
+		name    string
+		ref     string
+		want    string
+		wantErr bool
+	}{{
+		name: "fully qualified reference",
+		ref:  "docker.io/library/nginx:1.25@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31",
+		want: "pkg:docker/library/nginx@sha256%3A0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31?tag=1.25",
+	}, {
+		name: "bare name",
+		ref:  "nginx",
+		want: "pkg:docker/library/nginx",
+	}, {
+		name: "tag only",
+		ref:  "nginx:1.25",
+		want: "pkg:docker/library/nginx?tag=1.25",
+	}, {
+		name: "digest only",
+		ref:  "nginx@sha256:0d17b565c37b",
+		want: "pkg:docker/library/nginx@sha256%3A0d17b565c37b",
+	}, {
+		name: "docker hub user repository",
+		ref:  "bitnami/redis:7.2",
+		want: "pkg:docker/bitnami/redis?tag=7.2",
+	}, {
+		name: "custom registry",
+		ref:  "gcr.io/distroless/static:nonroot",
+		want: "pkg:docker/distroless/static?repository_url=gcr.io&tag=nonroot",
+	}, {
+		name: "registry with port",
+		ref:  "localhost:5000/app",
+		want: "pkg:docker/app?repository_url=localhost:5000",
+	}, {
+		name:    "empty reference",
+		ref:     "",
+		wantErr: true,
+	}, {
+		name:    "empty tag",
+		ref:     "nginx:",
+		wantErr: true,
+	}, {
+		name:    "uppercase repository",
+		ref:     "Nginx:1.25",
+		wantErr: true,
+	}}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := packageurl.FromImageReference(testCase.ref)
+			if testCase.wantErr {
+				if err == nil {
+					t.Fatalf("FromImageReference(%q): want error, got %#v", testCase.ref, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FromImageReference(%q): unexpected error: %v", testCase.ref, err)
+			}
+			if s := got.ToString(); s != testCase.want {
+				t.Fatalf("FromImageReference(%q): want %q, got %q", testCase.ref, testCase.want, s)
+			}
+		})
+	}
+}
+
+func TestCPEConversion(t *testing.T) {
+	testCases := []struct {
+		purl string
+		cpe  string
+	}{{
+		purl: "pkg:generic/openssl/openssl@1.1.1k",
+		cpe:  "cpe:2.3:a:openssl:openssl:1.1.1k:*:*:*:*:*:*:*",
+	}, {
+		purl: "pkg:generic/apache/http_server@2.4.57",
+		cpe:  "cpe:2.3:a:apache:http_server:2.4.57:*:*:*:*:*:*:*",
+	}, {
+		purl: "pkg:generic/haxx/curl",
+		cpe:  "cpe:2.3:a:haxx:curl:*:*:*:*:*:*:*:*",
+	}, {
+		purl: "pkg:generic/zlib@1.2.13",
+		cpe:  "cpe:2.3:a:*:zlib:1.2.13:*:*:*:*:*:*:*",
+	}, {
+		purl: "pkg:generic/gnu/gcc@12.2.0%3Abeta%2B1",
+		cpe:  `cpe:2.3:a:gnu:gcc:12.2.0\:beta\+1:*:*:*:*:*:*:*`,
+	}}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.purl, func(t *testing.T) {
+			p, err := packageurl.FromString(testCase.purl)
+			if err != nil {
+				t.Fatalf("FromString(%q): unexpected error: %v", testCase.purl, err)
+			}
+			cpe, err := p.ToCPE()
+			if err != nil {
+				t.Fatalf("ToCPE(%q): unexpected error: %v", testCase.purl, err)
+			}
+			if cpe != testCase.cpe {
+				t.Fatalf("ToCPE(%q): want %q, got %q", testCase.purl, testCase.cpe, cpe)
+			}
+
+			back, err := packageurl.FromCPE(cpe)
+			if err != nil {
+				t.Fatalf("FromCPE(%q): unexpected error: %v", cpe, err)
+			}
+			if !reflect.DeepEqual(p, back) {
+				t.Fatalf("FromCPE(%q):\nwant %#v\ngot %#v", cpe, p, back)
+			}
+		})
+	}
+}
+
+func TestCPEConversionLossy(t *testing.T) {
+	p := packageurl.PackageURL{
+		Type:      "maven",
+		Namespace: "Org.Example",
+		Name:      "My Lib",
+		Version:   "1.0",
+		Qualifiers: packageurl.Qualifiers{
+			{Key: "classifier", Value: "sources"},
+		},
+	}
+	cpe, err := p.ToCPE()
+	if err != nil {
+		t.Fatalf("ToCPE: unexpected error: %v", err)
+	}
+	if want := "cpe:2.3:a:org.example:my_lib:1.0:*:*:*:*:*:*:*"; cpe != want {
+		t.Fatalf("ToCPE: want %q, got %q", want, cpe)
+	}
+}
+
+func TestFromCPEInvalid(t *testing.T) {
+	testCases := []string{
+		"",
+		"cpe:/a:openssl:openssl:1.1.1k",
+		"cpe:2.3:a:openssl:openssl:1.1.1k",
+		"cpe:2.3:a:openssl:*:1.1.1k:*:*:*:*:*:*:*",
+		"cpe:2.3:a:openssl:open*:1.1.1k:*:*:*:*:*:*:*",
+	}
+	for _, cpe := range testCases {
+		if p, err := packageurl.FromCPE(cpe); err == nil {
+			t.Errorf("FromCPE(%q): want error, got %#v", cpe, p)
+		}
+	}
+}
+
+func TestContainerRef(t *testing.T) {
+	testCases := []struct {
+		purl    string
+		want    packageurl.ContainerReference
+		wantErr bool
+	}{{
+		purl: "pkg:docker/library/nginx@1.25",
+		want: packageurl.ContainerReference{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25"},
+	}, {
+		purl: "pkg:docker/library/nginx?tag=1.25",
+		want: packageurl.ContainerReference{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25"},
+	}, {
+		purl: "pkg:docker/customer/dockerimage@sha256%3A244fd47e07d10?repository_url=gcr.io",
+		want: packageurl.ContainerReference{Registry: "gcr.io", Repository: "customer/dockerimage", Digest: "sha256:244fd47e07d10"},
+	}, {
+		purl: "pkg:docker/library/nginx@sha256%3A244fd47e07d10?tag=1.25",
+		want: packageurl.ContainerReference{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25", Digest: "sha256:244fd47e07d10"},
+	}, {
+		purl: "pkg:docker/library/nginx@1.25?digest=sha256%3A244fd47e07d10",
+		want: packageurl.ContainerReference{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25", Digest: "sha256:244fd47e07d10"},
+	}, {
+		purl: "pkg:oci/debian@sha256%3A244fd47e07d10?repository_url=docker.io/library/debian&tag=latest",
+		want: packageurl.ContainerReference{Registry: "docker.io", Repository: "library/debian", Tag: "latest", Digest: "sha256:244fd47e07d10"},
+	}, {
+		purl: "pkg:oci/static@sha256%3A244fd47e07d10?repository_url=gcr.io/distroless",
+		want: packageurl.ContainerReference{Registry: "gcr.io", Repository: "distroless/static", Digest: "sha256:244fd47e07d10"},
+	}, {
+		purl:    "pkg:docker/library/nginx",
+		wantErr: true,
+	}, {
+		purl:    "pkg:npm/foo@1.0.0",
+		wantErr: true,
+	}}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.purl, func(t *testing.T) {
+			got, err := packageurl.MustParse(testCase.purl).ContainerRef()
+			if testCase.wantErr {
+				if err == nil {
+					t.Fatalf("ContainerRef(): want error, got %#v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ContainerRef(): unexpected error: %v", err)
+			}
+			if got != testCase.want {
+				t.Fatalf("ContainerRef():\nwant %#v\ngot %#v", testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestGoMajorVersion(t *testing.T) {
+	p := packageurl.MustParse("pkg:golang/github.com/foo/bar/v2@v2.1.0")
+	if p.Namespace != "github.com/foo/bar" || p.Name != "v2" || p.Version != "v2.1.0" {
+		t.Errorf("FromString(): want the major version suffix in the path and version %q, got %#v", "v2.1.0", p)
+	}
+	if got := p.GoImportPath(); got != "github.com/foo/bar/v2" {
+		t.Errorf("GoImportPath(): want %q, got %q", "github.com/foo/bar/v2", got)
+	}
+
+	testCases := []struct {
+		purl   string
+		major  int
+		wantOK bool
+	}{
+		{purl: "pkg:golang/github.com/foo/bar/v2@v2.1.0", major: 2, wantOK: true},
+		{purl: "pkg:golang/github.com/foo/bar/v10", major: 10, wantOK: true},
+		{purl: "pkg:golang/gopkg.in/yaml.v3@v3.0.1", major: 3, wantOK: true},
+		{purl: "pkg:golang/github.com/foo/bar@v1.4.0", major: 1, wantOK: true},
+		{purl: "pkg:golang/github.com/foo/bar@v0.3.1", major: 0, wantOK: true},
+		{purl: "pkg:golang/github.com/foo/bar@v2.0.0+incompatible", major: 2, wantOK: true},
+		{purl: "pkg:golang/github.com/foo/bar"},
+		{purl: "pkg:npm/foo@2.0.0"},
+	}
+	for _, testCase := range testCases {
+		major, ok := packageurl.MustParse(testCase.purl).GoMajorVersion()
+		if major != testCase.major || ok != testCase.wantOK {
+			t.Errorf("GoMajorVersion(%q): want %d, %v, got %d, %v", testCase.purl, testCase.major, testCase.wantOK, major, ok)
+		}
+	}
+}
+
+func TestGoImportPath(t *testing.T) {
+	testCases := []struct {
+		name       string
+		modulePath string
+		importPath string
+		version    string
+		purl       string
+	}{{
+		name:       "module root",
+		modulePath: "github.com/foo/bar",
+		importPath: "github.com/foo/bar",
+		version:    "v1.2.0",
+		purl:       "pkg:golang/github.com/foo/bar@v1.2.0",
+	}, {
+		name:       "subpackage",
+		modulePath: "github.com/foo/bar",
+		importPath: "github.com/foo/bar/internal/baz",
+		version:    "v1.2.0",
+		purl:       "pkg:golang/github.com/foo/bar@v1.2.0#internal/baz",
+	}, {
+		name:       "single segment module",
+		modulePath: "example",
+		importPath: "example/cmd",
+		purl:       "pkg:golang/example#cmd",
+	}}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			p, err := packageurl.FromGoImportPath(testCase.modulePath, testCase.importPath, testCase.version)
+			if err != nil {
+				t.Fatalf("FromGoImportPath(%q, %q): unexpected error: %v", testCase.modulePath, testCase.importPath, err)
+			}
+			if s := p.ToString(); s != testCase.purl {
+				t.Fatalf("FromGoImportPath(%q, %q): want %q, got %q", testCase.modulePath, testCase.importPath, testCase.purl, s)
+			}
+			if got := packageurl.MustParse(testCase.purl).GoImportPath(); got != testCase.importPath {
+				t.Fatalf("GoImportPath(%q): want %q, got %q", testCase.purl, testCase.importPath, got)
+			}
+		})
+	}
+
+	if got := packageurl.MustParse("pkg:npm/foo@1.0.0").GoImportPath(); got != "" {
+		t.Errorf("GoImportPath(): want empty import path for npm purl, got %q", got)
+	}
+	for _, importPath := range []string{"github.com/foo/barbaz", "github.com/other/bar", ""} {
+		if p, err := packageurl.FromGoImportPath("github.com/foo/bar", importPath, ""); err == nil {
+			t.Errorf("FromGoImportPath(%q): want error, got %#v", importPath, p)
+		}
+	}
+}
+
+func TestNativeCoordinate(t *testing.T) {
+	testCases := []struct {
+		purl       string
+		coordinate string
+	}{
+		{purl: "pkg:maven/org.apache.commons/commons-io@2.11.0?type=jar", coordinate: "org.apache.commons:commons-io:2.11.0"},
+		{purl: "pkg:maven/org.apache.commons/commons-io", coordinate: "org.apache.commons:commons-io"},
+		{purl: "pkg:npm/lodash@4.17.21", coordinate: "lodash@4.17.21"},
+		{purl: "pkg:npm/%40angular/core@16.0.0", coordinate: "@angular/core@16.0.0"},
+		{purl: "pkg:golang/github.com/foo/bar@v1.2.0#internal/baz", coordinate: "github.com/foo/bar@v1.2.0"},
+		{purl: "pkg:pypi/django@4.2.1", coordinate: "django==4.2.1"},
+	}
+	for _, testCase := range testCases {
+		got, err := packageurl.MustParse(testCase.purl).NativeCoordinate()
+		if err != nil {
+			t.Errorf("NativeCoordinate(%q): unexpected error: %v", testCase.purl, err)
+		} else if got != testCase.coordinate {
+			t.Errorf("NativeCoordinate(%q): want %q, got %q", testCase.purl, testCase.coordinate, got)
+		}
+	}
+
+	for _, p := range []packageurl.PackageURL{
+		packageurl.MustParse("pkg:deb/debian/curl@7.50.3-1"),
+		{Type: "maven", Name: "commons-io", Version: "2.11.0"},
+	} {
+		if c, err := p.NativeCoordinate(); err == nil {
+			t.Errorf("NativeCoordinate(%#v): want error, got %q", p, c)
+		}
+	}
+}
+
+func TestGitHubURL(t *testing.T) {
+	testCases := []struct {
+		purl string
+		url  string
+	}{
+		{purl: "pkg:github/package-url/purl-spec@244fd47e07d1004", url: "https://github.com/package-url/purl-spec/tree/244fd47e07d1004"},
+		{purl: "pkg:github/package-url/purl-spec@v1.0.0", url: "https://github.com/package-url/purl-spec/tree/v1.0.0"},
+		{purl: "pkg:github/package-url/purl-spec", url: "https://github.com/package-url/purl-spec"},
+	}
+	for _, testCase := range testCases {
+		got, err := packageurl.MustParse(testCase.purl).GitHubURL()
+		if err != nil {
+			t.Errorf("GitHubURL(%q): unexpected error: %v", testCase.purl, err)
+		} else if got != testCase.url {
+			t.Errorf("GitHubURL(%q): want %q, got %q", testCase.purl, testCase.url, got)
+		}
+
+		p, err := packageurl.FromGitHubURL(testCase.url)
+		if err != nil {
+			t.Errorf("FromGitHubURL(%q): unexpected error: %v", testCase.url, err)
+		} else if s := p.ToString(); s != testCase.purl {
+			t.Errorf("FromGitHubURL(%q): want %q, got %q", testCase.url, testCase.purl, s)
+		}
+	}
+
+	for _, purl := range []string{"pkg:npm/foo@1.0.0", "pkg:github/purl-spec@v1.0.0"} {
+		if u, err := packageurl.MustParse(purl).GitHubURL(); err == nil {
+			t.Errorf("GitHubURL(%q): want error, got %q", purl, u)
+		}
+	}
+}
+
+func TestFromGitHubURL(t *testing.T) {
+	testCases := []struct {
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{url: "https://github.com/Package-URL/Purl-Spec", want: "pkg:github/package-url/purl-spec"},
+		{url: "https://www.github.com/package-url/purl-spec.git", want: "pkg:github/package-url/purl-spec"},
+		{url: "https://github.com/package-url/purl-spec/releases/tag/v1.0.0", want: "pkg:github/package-url/purl-spec@v1.0.0"},
+		{url: "https://github.com/package-url/purl-spec/tree/feature/x/", want: "pkg:github/package-url/purl-spec@feature%2Fx"},
+		{url: "https://github.com/package-url", wantErr: true},
+		{url: "https://github.com/package-url/purl-spec/issues/1", wantErr: true},
+		{url: "https://gitlab.com/package-url/purl-spec", wantErr: true},
+		{url: "ftp://github.com/package-url/purl-spec", wantErr: true},
+	}
+	for _, testCase := range testCases {
+		p, err := packageurl.FromGitHubURL(testCase.url)
+		if testCase.wantErr {
+			if err == nil {
+				t.Errorf("FromGitHubURL(%q): want error, got %#v", testCase.url, p)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("FromGitHubURL(%q): unexpected error: %v", testCase.url, err)
+		} else if s := p.ToString(); s != testCase.want {
+			t.Errorf("FromGitHubURL(%q): want %q, got %q", testCase.url, testCase.want, s)
+		}
+	}
+}
+
+func TestBitbucketURL(t *testing.T) {
+	testCases := []struct {
+		purl string
+		url  string
+	}{
+		{purl: "pkg:bitbucket/birkenfeld/pygments-main@244fd47e07d1014f0aed9c", url: "https://bitbucket.org/birkenfeld/pygments-main/src/244fd47e07d1014f0aed9c"},
+		{purl: "pkg:bitbucket/birkenfeld/pygments-main", url: "https://bitbucket.org/birkenfeld/pygments-main"},
+	}
+	for _, testCase := range testCases {
+		got, err := packageurl.MustParse(testCase.purl).BitbucketURL()
+		if err != nil {
+			t.Errorf("BitbucketURL(%q): unexpected error: %v", testCase.purl, err)
+		} else if got != testCase.url {
+			t.Errorf("BitbucketURL(%q): want %q, got %q", testCase.purl, testCase.url, got)
+		}
+
+		p, err := packageurl.FromBitbucketURL(testCase.url)
+		if err != nil {
+			t.Errorf("FromBitbucketURL(%q): unexpected error: %v", testCase.url, err)
+		} else if s := p.ToString(); s != testCase.purl {
+			t.Errorf("FromBitbucketURL(%q): want %q, got %q", testCase.url, testCase.purl, s)
+		}
+	}
+
+	if u, err := packageurl.MustParse("pkg:github/birkenfeld/pygments-main").BitbucketURL(); err == nil {
+		t.Errorf("BitbucketURL(): want error for github purl, got %q", u)
+	}
+}
+
+func TestFromBitbucketURL(t *testing.T) {
+	testCases := []struct {
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{url: "https://bitbucket.org/Birkenfeld/Pygments-Main/src/v2.0", want: "pkg:bitbucket/birkenfeld/pygments-main@v2.0"},
+		{url: "https://bitbucket.org/birkenfeld/pygments-main/", want: "pkg:bitbucket/birkenfeld/pygments-main"},
+		{url: "https://bitbucket.org/birkenfeld/pygments-main/tree/v2.0", wantErr: true},
+		{url: "https://github.com/birkenfeld/pygments-main", wantErr: true},
+	}
+	for _, testCase := range testCases {
+		p, err := packageurl.FromBitbucketURL(testCase.url)
+		if testCase.wantErr {
+			if err == nil {
+				t.Errorf("FromBitbucketURL(%q): want error, got %#v", testCase.url, p)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("FromBitbucketURL(%q): unexpected error: %v", testCase.url, err)
+		} else if s := p.ToString(); s != testCase.want {
+			t.Errorf("FromBitbucketURL(%q): want %q, got %q", testCase.url, testCase.want, s)
+		}
+	}
+}
+
+func TestSPDXExternalRef(t *testing.T) {
+	testCases := []string{
+		"pkg:npm/%40angular/animation@12.3.1",
+		"pkg:maven/org.apache.xmlgraphics/batik-anim@1.9.1?classifier=sources&repository_url=repo.spring.io/release",
+		"pkg:golang/github.com/gorilla/context@234fd47e07d1004f0aed9c#api",
+	}
+	for _, purl := range testCases {
+		p := packageurl.MustParse(purl)
+		ref := p.ToSPDXExternalRef()
+		want := packageurl.SPDXExternalRef{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: purl}
+		if ref != want {
+			t.Errorf("ToSPDXExternalRef(%q):\nwant %#v\ngot %#v", purl, want, ref)
+		}
+		back, err := packageurl.FromSPDXExternalRef(ref)
+		if err != nil {
+			t.Errorf("FromSPDXExternalRef(%#v): unexpected error: %v", ref, err)
+		} else if !reflect.DeepEqual(p, back) {
+			t.Errorf("FromSPDXExternalRef(%#v):\nwant %#v\ngot %#v", ref, p, back)
+		}
+	}
+
+	invalid := []packageurl.SPDXExternalRef{
+		{ReferenceCategory: "SECURITY", ReferenceType: "cpe23Type", ReferenceLocator: "cpe:2.3:a:openssl:openssl:1.1.1k:*:*:*:*:*:*:*"},
+		{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "maven-central", ReferenceLocator: "org.apache:commons:1.0"},
+		{ReferenceCategory: "PACKAGE_MANAGER", ReferenceType: "purl", ReferenceLocator: "npm/foo"},
+	}
+	for _, ref := range invalid {
+		if p, err := packageurl.FromSPDXExternalRef(ref); err == nil {
+			t.Errorf("FromSPDXExternalRef(%#v): want error, got %#v", ref, p)
+		}
+	}
+}
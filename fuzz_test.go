@@ -23,14 +23,42 @@ SOFTWARE.
 package packageurl
 
 import (
-	"fmt"
+	"encoding/json"
+	"os"
+	"reflect"
 	"testing"
 )
 
 func FuzzFromString(f *testing.F) {
+	// seed the corpus with the purls of the test suite, if it was downloaded.
+	if data, err := os.ReadFile("testdata/test-suite-data.json"); err == nil {
+		var testData []struct {
+			PURL          string `json:"purl"`
+			CanonicalPURL string `json:"canonical_purl"`
+		}
+		if err := json.Unmarshal(data, &testData); err != nil {
+			f.Fatal(err)
+		}
+		for _, tc := range testData {
+			f.Add(tc.PURL)
+			f.Add(tc.CanonicalPURL)
+		}
+	}
+
 	f.Fuzz(func(t *testing.T, s string) {
 		// Test that parsing doesn't panic.
-		_, _ = FromString(s)
-		fmt.Print(s)
+		p, err := FromString(s)
+		if err != nil {
+			return
+		}
+		// Test that a parsed purl survives a round-trip through its string form.
+		out := p.ToString()
+		p2, err := FromString(out)
+		if err != nil {
+			t.Fatalf("FromString(%q) = %q, which fails to parse: %v", s, out, err)
+		}
+		if !reflect.DeepEqual(p, p2) {
+			t.Fatalf("FromString(%q) = %q, which parses differently:\n%#v\n%#v", s, out, p, p2)
+		}
 	})
 }
@@ -0,0 +1,107 @@
+/*
+Copyright (c) the purl authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package packageurl
+
+import "strings"
+
+// Metadata describes the structural expectations of a purl type.
+type Metadata struct {
+	// NamespaceRequired reports whether purls of the type must have a
+	// namespace.
+	NamespaceRequired bool
+	// NamespaceForbidden reports whether purls of the type must not have a
+	// namespace, as the type's registry has a single flat namespace.
+	NamespaceForbidden bool
+	// VersionRequired reports whether purls of the type must have a version.
+	VersionRequired bool
+	// VersionForbidden reports whether purls of the type must not have a
+	// version, as a version is meaningless for the type.
+	VersionForbidden bool
+	// CaseSensitiveName reports whether the name is kept as-is during
+	// normalization. If false, the name is lowercased.
+	CaseSensitiveName bool
+	// DefaultRepository is the URL of the repository purls of the type refer
+	// to when they have no repository_url qualifier. It is empty if the type
+	// has no default repository.
+	DefaultRepository string
+	// DefaultNamespace is the namespace implied by purls of the type which
+	// have none, such as "library" for Docker Hub images. It is empty if the
+	// type has no default namespace.
+	DefaultNamespace string
+}
+
+// typeMetadata holds the metadata of the known types and of the types with
+// additional rules, keyed by type.
+// See https://github.com/package-url/purl-spec/blob/master/PURL-TYPES.rst
+var typeMetadata = map[string]Metadata{
+	TypeAlpm:        {},
+	TypeApk:         {},
+	TypeBitbucket:   {DefaultRepository: "https://bitbucket.org"},
+	TypeBitnami:     {DefaultRepository: "https://downloads.bitnami.com/files/stacksmith"},
+	TypeCargo:       {NamespaceForbidden: true, CaseSensitiveName: true, DefaultRepository: "https://crates.io"},
+	TypeCocoapods:   {NamespaceForbidden: true, CaseSensitiveName: true, DefaultRepository: "https://cdn.cocoapods.org"},
+	TypeComposer:    {NamespaceRequired: true, DefaultRepository: "https://packagist.org"},
+	TypeConan:       {CaseSensitiveName: true, DefaultRepository: "https://center.conan.io"},
+	TypeConda:       {CaseSensitiveName: true, DefaultRepository: "https://repo.anaconda.com"},
+	TypeCran:        {NamespaceForbidden: true, VersionRequired: true, CaseSensitiveName: true, DefaultRepository: "https://cran.r-project.org"},
+	TypeDebian:      {},
+	TypeDocker:      {CaseSensitiveName: true, DefaultRepository: "https://hub.docker.com", DefaultNamespace: "library"},
+	TypeGem:         {NamespaceForbidden: true, CaseSensitiveName: true, DefaultRepository: "https://rubygems.org"},
+	TypeGeneric:     {CaseSensitiveName: true},
+	TypeGithub:      {DefaultRepository: "https://github.com"},
+	TypeGolang:      {},
+	TypeHackage:     {NamespaceForbidden: true, CaseSensitiveName: true, DefaultRepository: "https://hackage.haskell.org"},
+	TypeHex:         {CaseSensitiveName: true, DefaultRepository: "https://repo.hex.pm"},
+	TypeHuggingface: {CaseSensitiveName: true, DefaultRepository: "https://huggingface.co"},
+	TypeMaven:       {CaseSensitiveName: true, DefaultRepository: "https://repo.maven.apache.org/maven2"},
+	TypeMLFlow:      {CaseSensitiveName: true},
+	TypeNPM:         {CaseSensitiveName: true, DefaultRepository: "https://registry.npmjs.org"},
+	TypeNuget:       {NamespaceForbidden: true, CaseSensitiveName: true, DefaultRepository: "https://www.nuget.org"},
+	TypeOCI:         {CaseSensitiveName: true},
+	TypePub:         {NamespaceForbidden: true, CaseSensitiveName: true, DefaultRepository: "https://pub.dartlang.org"},
+	TypePyPi:        {NamespaceForbidden: true, DefaultRepository: "https://pypi.org"},
+	TypeQpkg:        {CaseSensitiveName: true},
+	TypeRPM:         {CaseSensitiveName: true},
+	TypeSWID:        {CaseSensitiveName: true},
+	TypeSwift:       {NamespaceRequired: true, VersionRequired: true, CaseSensitiveName: true},
+	TypeSourceforge: {DefaultRepository: "https://sourceforge.net"},
+	TypeWORDPRESS:   {DefaultRepository: "https://wordpress.org"},
+}
+
+// TypeMetadata returns the metadata of the purl type t, which is matched
+// case-insensitively. It returns false if t is neither a known type nor a type
+// registered with RegisterType.
+//
+// Some types have rules which metadata can't express: mlflow names, for
+// example, are only lowercased when hosted on Databricks.
+func TypeMetadata(t string) (Metadata, bool) {
+	return lookupMetadata(strings.ToLower(t))
+}
+
+// DefaultNamespace returns the namespace implied by purls of type t which have
+// none, such as "library" for docker. It returns false if t has no default
+// namespace.
+func DefaultNamespace(t string) (string, bool) {
+	md, _ := TypeMetadata(t)
+	return md.DefaultNamespace, md.DefaultNamespace != ""
+}
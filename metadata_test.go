@@ -0,0 +1,102 @@
+/*
+Copyright (c) the purl authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package packageurl_test
+
+import (
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+func TestTypeMetadata(t *testing.T) {
+	testCases := []struct {
+		typ    string
+		want   packageurl.Metadata
+		wantOK bool
+	}{{
+		typ:    "maven",
+		want:   packageurl.Metadata{CaseSensitiveName: true, DefaultRepository: "https://repo.maven.apache.org/maven2"},
+		wantOK: true,
+	}, {
+		typ:    "swift",
+		want:   packageurl.Metadata{NamespaceRequired: true, VersionRequired: true, CaseSensitiveName: true},
+		wantOK: true,
+	}, {
+		typ:    "CRAN",
+		want:   packageurl.Metadata{NamespaceForbidden: true, VersionRequired: true, CaseSensitiveName: true, DefaultRepository: "https://cran.r-project.org"},
+		wantOK: true,
+	}, {
+		typ:    "unknown",
+		wantOK: false,
+	}}
+
+	for _, testCase := range testCases {
+		got, ok := packageurl.TypeMetadata(testCase.typ)
+		if ok != testCase.wantOK {
+			t.Errorf("TypeMetadata(%q): want ok %v, got %v", testCase.typ, testCase.wantOK, ok)
+		}
+		if got != testCase.want {
+			t.Errorf("TypeMetadata(%q):\nwant %#v\ngot %#v", testCase.typ, testCase.want, got)
+		}
+	}
+}
+
+func TestTypeMetadataKnownTypes(t *testing.T) {
+	for typ := range packageurl.KnownTypes {
+		md, ok := packageurl.TypeMetadata(typ)
+		if !ok {
+			t.Errorf("TypeMetadata(%q): no metadata for known type", typ)
+			continue
+		}
+		if typ == packageurl.TypeMLFlow {
+			// mlflow names are lowercased depending on their repository.
+			continue
+		}
+		p := packageurl.PackageURL{Type: typ, Namespace: "ns", Name: "Name", Version: "1.0"}
+		if err := p.Normalize(); err != nil {
+			// some types require qualifiers, which don't matter here.
+			continue
+		}
+		if got := p.Name == "Name"; got != md.CaseSensitiveName {
+			t.Errorf("TypeMetadata(%q): CaseSensitiveName is %v, but Normalize gave name %q", typ, md.CaseSensitiveName, p.Name)
+		}
+	}
+}
+
+func TestDefaultNamespace(t *testing.T) {
+	testCases := []struct {
+		typ    string
+		want   string
+		wantOK bool
+	}{
+		{typ: "docker", want: "library", wantOK: true},
+		{typ: "Docker", want: "library", wantOK: true},
+		{typ: "maven"},
+		{typ: "unknown"},
+	}
+	for _, testCase := range testCases {
+		got, ok := packageurl.DefaultNamespace(testCase.typ)
+		if got != testCase.want || ok != testCase.wantOK {
+			t.Errorf("DefaultNamespace(%q): want (%q, %v), got (%q, %v)", testCase.typ, testCase.want, testCase.wantOK, got, ok)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright (c) the purl authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package packageurl
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// typeRule holds the normalization and validation of a purl type which isn't
+// handled by the built-in type switches. Nil functions are skipped.
+type typeRule struct {
+	adjustNamespace func(namespace string) string
+	adjustName      func(name string) string
+	adjustVersion   func(version string) string
+	validate        func(p PackageURL) error
+}
+
+// wordpressSlugPattern describes a valid WordPress plugin or theme slug.
+var wordpressSlugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9\-_]*$`)
+
+// typeRules holds the rules of types added on top of the built-in type
+// switches, keyed by type.
+var typeRules = map[string]typeRule{
+	// SourceForge project names are case-insensitive and lowercased.
+	TypeSourceforge: {
+		adjustName: strings.ToLower,
+	},
+	// WordPress plugins and themes are identified by their lowercase slug.
+	TypeWORDPRESS: {
+		adjustName: strings.ToLower,
+		validate: func(p PackageURL) error {
+			if !wordpressSlugPattern.MatchString(p.Name) {
+				return fmt.Errorf("invalid wordpress slug: %q", p.Name)
+			}
+			return nil
+		},
+	},
+}
+
+// TypeDefinition describes a purl type registered with RegisterType: its
+// metadata, and the hooks normalizing and validating its purls. Nil hooks are
+// skipped.
+type TypeDefinition struct {
+	Metadata
+	// AdjustNamespace, AdjustName and AdjustVersion normalize the namespace,
+	// name and version of purls of the type.
+	AdjustNamespace func(namespace string) string
+	AdjustName      func(name string) string
+	AdjustVersion   func(version string) string
+	// Validate validates a normalized purl of the type, after the checks
+	// described by Metadata have passed.
+	Validate func(p PackageURL) error
+}
+
+var (
+	registeredTypesMu sync.RWMutex
+	registeredTypes   = map[string]TypeDefinition{}
+)
+
+// RegisterType registers purlType, such as a private package type used within
+// an organization, so that Normalize, and so FromString and Validate, apply
+// def to its purls. The type is matched case-insensitively. As for the
+// built-in types, names are lowercased unless def.CaseSensitiveName is set,
+// before def.AdjustName is applied. TypeMetadata then reports def.Metadata for
+// the type.
+//
+// Built-in types, those of KnownTypes and those with rules of their own such
+// as sourceforge, can't be overridden: RegisterType panics if purlType is one
+// of them, or isn't a valid type. Registering a type again replaces its
+// previous definition.
+func RegisterType(purlType string, def TypeDefinition) {
+	typ := strings.ToLower(purlType)
+	if !validType(typ) {
+		panic(fmt.Sprintf("packageurl: RegisterType: invalid type %q", purlType))
+	}
+	if isBuiltinType(typ) {
+		panic(fmt.Sprintf("packageurl: RegisterType: built-in type %q can't be overridden", typ))
+	}
+	registeredTypesMu.Lock()
+	defer registeredTypesMu.Unlock()
+	registeredTypes[typ] = def
+}
+
+// isBuiltinType reports whether typ is a known type, or has built-in metadata
+// or rules in any of the per-type tables and type switches.
+func isBuiltinType(typ string) bool {
+	_, known := KnownTypes[typ]
+	_, hasMetadata := typeMetadata[typ]
+	_, hasRule := typeRules[typ]
+	_, hasSwitchRule := switchRuleTypes[typ]
+	_, expectsVersion := versionExpectedTypes[typ]
+	_, hasSourceArch := sourceArchs[typ]
+	_, foldsCase := caseInsensitiveParts[typ]
+	return known || hasMetadata || hasRule || hasSwitchRule || expectsVersion || hasSourceArch || foldsCase
+}
+
+// registeredType returns the definition registered for typ, if any.
+func registeredType(typ string) (TypeDefinition, bool) {
+	registeredTypesMu.RLock()
+	defer registeredTypesMu.RUnlock()
+	def, ok := registeredTypes[typ]
+	return def, ok
+}
+
+// lookupTypeRule returns the rule of typ, either built-in or derived from its
+// registered definition.
+func lookupTypeRule(typ string) (typeRule, bool) {
+	if rule, ok := typeRules[typ]; ok {
+		return rule, true
+	}
+	def, ok := registeredType(typ)
+	if !ok {
+		return typeRule{}, false
+	}
+	adjustName := def.AdjustName
+	if !def.CaseSensitiveName {
+		adjustName = func(name string) string {
+			name = strings.ToLower(name)
+			if def.AdjustName != nil {
+				name = def.AdjustName(name)
+			}
+			return name
+		}
+	}
+	return typeRule{
+		adjustNamespace: def.AdjustNamespace,
+		adjustName:      adjustName,
+		adjustVersion:   def.AdjustVersion,
+		validate:        def.Validate,
+	}, true
+}
+
+// lookupMetadata returns the metadata of typ, either built-in or registered.
+func lookupMetadata(typ string) (Metadata, bool) {
+	if md, ok := typeMetadata[typ]; ok {
+		return md, true
+	}
+	def, ok := registeredType(typ)
+	return def.Metadata, ok
+}
+
+var (
+	validatorsMu sync.RWMutex
+	validators   = map[string][]func(PackageURL) error{}
+)
+
+// RegisterValidator registers fn as an additional validation of purls of the
+// given type, for example to require a qualifier used within an organization.
+//
+// Registered validators run during Normalize, and so also during FromString
+// and Validate, after the built-in rules of the spec have passed. They are
+// called in registration order with the normalized purl, and the first error
+// is returned wrapped, so it can be matched with errors.Is and errors.As.
+func RegisterValidator(purlType string, fn func(PackageURL) error) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	typ := strings.ToLower(purlType)
+	validators[typ] = append(validators[typ], fn)
+}
+
+// runValidators runs the validators registered for the type of p.
+func runValidators(p PackageURL) error {
+	validatorsMu.RLock()
+	fns := validators[p.Type]
+	validatorsMu.RUnlock()
+	for _, fn := range fns {
+		if err := fn(p); err != nil {
+			return fmt.Errorf("%s validator failed: %w", p.Type, err)
+		}
+	}
+	return nil
+}
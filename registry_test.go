@@ -0,0 +1,112 @@
+/*
+Copyright (c) the purl authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package packageurl_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+func TestRegisterValidator(t *testing.T) {
+	errMissingTeam := errors.New("missing team qualifier")
+	packageurl.RegisterValidator("Internal", func(p packageurl.PackageURL) error {
+		for _, q := range p.Qualifiers {
+			if q.Key == "team" {
+				return nil
+			}
+		}
+		return errMissingTeam
+	})
+
+	if _, err := packageurl.FromString("pkg:internal/billing@1.0?Team=payments"); err != nil {
+		t.Fatalf("FromString(): unexpected error: %v", err)
+	}
+
+	_, err := packageurl.FromString("pkg:internal/billing@1.0")
+	if !errors.Is(err, errMissingTeam) {
+		t.Fatalf("FromString(): want error wrapping %v, got %v", errMissingTeam, err)
+	}
+
+	p := packageurl.PackageURL{Type: "internal", Name: "billing"}
+	if err := p.Validate(); !errors.Is(err, errMissingTeam) {
+		t.Fatalf("Validate(): want error wrapping %v, got %v", errMissingTeam, err)
+	}
+
+	// validators only apply to the type they were registered for.
+	if _, err := packageurl.FromString("pkg:generic/billing@1.0"); err != nil {
+		t.Fatalf("FromString(): unexpected error for other type: %v", err)
+	}
+}
+
+func TestRegisterType(t *testing.T) {
+	packageurl.RegisterType("Acme", packageurl.TypeDefinition{
+		Metadata: packageurl.Metadata{
+			NamespaceRequired: true,
+			DefaultRepository: "https://packages.acme.example",
+		},
+		AdjustNamespace: strings.ToLower,
+		AdjustVersion:   func(version string) string { return strings.TrimPrefix(version, "v") },
+		Validate: func(p packageurl.PackageURL) error {
+			if strings.Contains(p.Name, "_") {
+				return fmt.Errorf("acme names must not contain '_': %q", p.Name)
+			}
+			return nil
+		},
+	})
+
+	p, err := packageurl.FromString("pkg:acme/Payments/Billing@v1.2.0")
+	if err != nil {
+		t.Fatalf("FromString(): unexpected error: %v", err)
+	}
+	if want := "pkg:acme/payments/billing@1.2.0"; p.ToString() != want {
+		t.Errorf("FromString(): want %q, got %q", want, p.ToString())
+	}
+
+	for _, purl := range []string{"pkg:acme/billing@1.2.0", "pkg:acme/payments/billing_api@1.2.0"} {
+		if p, err := packageurl.FromString(purl); err == nil {
+			t.Errorf("FromString(%q): want error, got %#v", purl, p)
+		}
+	}
+
+	md, ok := packageurl.TypeMetadata("acme")
+	if !ok || md.DefaultRepository != "https://packages.acme.example" {
+		t.Errorf("TypeMetadata(%q): want registered metadata, got %#v, %v", "acme", md, ok)
+	}
+}
+
+func TestRegisterTypeBuiltin(t *testing.T) {
+	// julia isn't a known type, but has rules of its own.
+	for _, typ := range []string{"npm", "Maven", "sourceforge", "julia", "Julia", "not a type"} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("RegisterType(%q): want panic, got none", typ)
+				}
+			}()
+			packageurl.RegisterType(typ, packageurl.TypeDefinition{})
+		}()
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright (c) the purl authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package packageurl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Scanner reads purls from an io.Reader, one per line, parsing them lazily.
+// Blank lines are skipped. It is used like bufio.Scanner:
+//
+//	s := packageurl.NewScanner(r)
+//	for s.Scan() {
+//		p := s.PackageURL()
+//		...
+//	}
+//	if err := s.Err(); err != nil {
+//		...
+//	}
+//
+// Scanning stops at the first line which fails to parse, which is then
+// reported by Err.
+type Scanner struct {
+	lines        *bufio.Scanner
+	skipComments bool
+	line         int
+	purl         PackageURL
+	err          error
+}
+
+// NewScanner returns a new Scanner reading from r.
+func NewScanner(r io.Reader) *Scanner {
+	return &Scanner{lines: bufio.NewScanner(r)}
+}
+
+// SkipComments sets whether lines starting with '#' are skipped as comments.
+// It must be called before the first call to Scan.
+func (s *Scanner) SkipComments(skip bool) {
+	s.skipComments = skip
+}
+
+// Scan advances the Scanner to the next purl, which is then available through
+// PackageURL. It returns false when the input is exhausted or an error
+// occurred, after which Err reports the error, if any.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+	for s.lines.Scan() {
+		s.line++
+		line := strings.TrimSpace(s.lines.Text())
+		if line == "" || s.skipComments && strings.HasPrefix(line, "#") {
+			continue
+		}
+		p, err := FromString(line)
+		if err != nil {
+			s.purl = PackageURL{}
+			s.err = fmt.Errorf("line %d: %w", s.line, err)
+			return false
+		}
+		s.purl = p
+		return true
+	}
+	s.purl = PackageURL{}
+	s.err = s.lines.Err()
+	return false
+}
+
+// PackageURL returns the purl parsed by the most recent call to Scan.
+func (s *Scanner) PackageURL() PackageURL {
+	return s.purl
+}
+
+// Err returns the first error encountered by the Scanner, either while reading
+// or while parsing a line.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// embeddedPurlPattern matches a purl within text. A purl starts with "pkg:" at
+// a word boundary and ends before whitespace, a quote, a comma or a bracket.
+var embeddedPurlPattern = regexp.MustCompile("\\bpkg:[^\\s\"'`,<>()\\[\\]{}]+")
+
+// ExtractAll returns the purls found in text, such as a log or a document, in
+// order of appearance. Punctuation ending a sentence, such as a trailing '.',
+// isn't taken as part of a purl. Candidates which fail to parse are skipped.
+func ExtractAll(text string) []PackageURL {
+	var purls []PackageURL
+	for _, candidate := range embeddedPurlPattern.FindAllString(text, -1) {
+		candidate = strings.TrimRight(candidate, ".;:!?")
+		if p, err := FromString(candidate); err == nil {
+			purls = append(purls, p)
+		}
+	}
+	return purls
+}
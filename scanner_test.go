@@ -0,0 +1,104 @@
+/*
+Copyright (c) the purl authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package packageurl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+func TestScanner(t *testing.T) {
+	input := `# dependencies
+pkg:npm/foo@1.0.0
+
+pkg:pypi/requests@2.31.0
+  pkg:golang/github.com/foo/bar@v1.0.0#internal/baz
+pkg:cran/A3
+pkg:npm/never-reached@1.0.0
+`
+	s := packageurl.NewScanner(strings.NewReader(input))
+	s.SkipComments(true)
+
+	var got []string
+	for s.Scan() {
+		p := s.PackageURL()
+		got = append(got, p.ToString())
+	}
+	want := []string{
+		"pkg:npm/foo@1.0.0",
+		"pkg:pypi/requests@2.31.0",
+		"pkg:golang/github.com/foo/bar@v1.0.0#internal/baz",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("Scan(): want %q, got %q", want, got)
+	}
+	err := s.Err()
+	if err == nil || !strings.HasPrefix(err.Error(), "line 6:") {
+		t.Fatalf("Err(): want error for line 6, got %v", err)
+	}
+	if s.Scan() {
+		t.Fatal("Scan(): want false after an error")
+	}
+}
+
+func TestScannerComments(t *testing.T) {
+	s := packageurl.NewScanner(strings.NewReader("# comment\npkg:npm/foo\n"))
+	if s.Scan() {
+		t.Fatalf("Scan(): want comment to fail without SkipComments, got %v", s.PackageURL())
+	}
+	if s.Err() == nil {
+		t.Fatal("Err(): want error for comment line without SkipComments")
+	}
+
+	s = packageurl.NewScanner(strings.NewReader(""))
+	if s.Scan() || s.Err() != nil {
+		t.Fatalf("Scan(): want no purls and no error for empty input, got %v", s.Err())
+	}
+}
+
+func TestExtractAll(t *testing.T) {
+	text := `Found pkg:npm/%40angular/core@12.3.1 and "pkg:pypi/django@1.11.1", then
+pkg:maven/org.apache.commons/io@1.3.4?type=jar, plus a broken pkg:/ and
+(pkg:golang/github.com/gorilla/context@v1.1.1#pkg). Not a purl: xpkg:npm/foo.`
+	want := []string{
+		"pkg:npm/%40angular/core@12.3.1",
+		"pkg:pypi/django@1.11.1",
+		"pkg:maven/org.apache.commons/io@1.3.4?type=jar",
+		"pkg:golang/github.com/gorilla/context@v1.1.1#pkg",
+	}
+
+	got := packageurl.ExtractAll(text)
+	if len(got) != len(want) {
+		t.Fatalf("ExtractAll(): want %d purls, got %d: %v", len(want), len(got), got)
+	}
+	for i := range want {
+		if s := got[i].ToString(); s != want[i] {
+			t.Errorf("ExtractAll()[%d]: want %q, got %q", i, want[i], s)
+		}
+	}
+
+	if got := packageurl.ExtractAll("no purls here"); got != nil {
+		t.Errorf("ExtractAll(): want no purls, got %v", got)
+	}
+}
@@ -0,0 +1,346 @@
+/*
+Copyright (c) the purl authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package packageurl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VersionCompare compares the versions of p and other, which must be of the
+// same type, returning -1, 0 or +1 if p's version is lower than, equal to or
+// higher than other's. The comparison follows the ordering of the type's
+// ecosystem, on a best-effort basis:
+//
+//   - npm, cargo and golang: semantic versioning, where build metadata is
+//     ignored and a missing minor or patch number is taken as 0
+//   - gem: RubyGems versions, where a segment with letters marks a prerelease
+//   - pypi: PEP 440, ignoring local version labels
+//
+// It returns an error if the types differ, if the ordering is undefined for
+// the type or if either version is missing or invalid for the type.
+func (p PackageURL) VersionCompare(other PackageURL) (int, error) {
+	if p.Type != other.Type {
+		return 0, fmt.Errorf("cannot compare versions of different types: %q and %q", p.Type, other.Type)
+	}
+	var compare func(a, b string) (int, error)
+	switch p.Type {
+	case TypeNPM, TypeCargo, TypeGolang:
+		compare = compareSemver
+	case TypeGem:
+		compare = compareGemVersions
+	case TypePyPi:
+		compare = comparePEP440
+	default:
+		return 0, fmt.Errorf("version ordering is not defined for type %q", p.Type)
+	}
+	if p.Version == "" || other.Version == "" {
+		return 0, fmt.Errorf("purl is missing version")
+	}
+	return compare(p.Version, other.Version)
+}
+
+// semver is a parsed semantic version.
+type semver struct {
+	core []string
+	pre  []string
+}
+
+func parseSemver(v string) (semver, error) {
+	s := strings.TrimPrefix(v, "v")
+	s, _, _ = strings.Cut(s, "+")
+	core, pre, hasPre := strings.Cut(s, "-")
+	var sv semver
+	sv.core = strings.Split(core, ".")
+	if len(sv.core) > 3 {
+		return semver{}, fmt.Errorf("invalid semantic version: %q", v)
+	}
+	for _, n := range sv.core {
+		if !isNumeric(n) {
+			return semver{}, fmt.Errorf("invalid semantic version: %q", v)
+		}
+	}
+	for len(sv.core) < 3 {
+		sv.core = append(sv.core, "0")
+	}
+	if hasPre {
+		sv.pre = strings.Split(pre, ".")
+		for _, id := range sv.pre {
+			if id == "" {
+				return semver{}, fmt.Errorf("invalid semantic version: %q", v)
+			}
+		}
+	}
+	return sv, nil
+}
+
+func compareSemver(a, b string) (int, error) {
+	va, err := parseSemver(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parseSemver(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := range va.core {
+		if c := compareNumeric(va.core[i], vb.core[i]); c != 0 {
+			return c, nil
+		}
+	}
+	// a version without a prerelease is higher than any of its prereleases.
+	switch {
+	case len(va.pre) == 0 && len(vb.pre) == 0:
+		return 0, nil
+	case len(va.pre) == 0:
+		return 1, nil
+	case len(vb.pre) == 0:
+		return -1, nil
+	}
+	for i := 0; i < len(va.pre) && i < len(vb.pre); i++ {
+		x, y := va.pre[i], vb.pre[i]
+		var c int
+		switch xNum, yNum := isNumeric(x), isNumeric(y); {
+		case xNum && yNum:
+			c = compareNumeric(x, y)
+		case xNum:
+			c = -1
+		case yNum:
+			c = 1
+		default:
+			c = strings.Compare(x, y)
+		}
+		if c != 0 {
+			return c, nil
+		}
+	}
+	return compareInts(len(va.pre), len(vb.pre)), nil
+}
+
+// gemSegments splits a RubyGems version into its numeric and alphabetic
+// segments, e.g. "1.0.0.rc1" into "1", "0", "0", "rc" and "1". As in RubyGems,
+// a '-' is read as ".pre.".
+func gemSegments(v string) ([]string, error) {
+	s := strings.ReplaceAll(strings.TrimSpace(v), "-", ".pre.")
+	var segments []string
+	for _, part := range strings.Split(s, ".") {
+		if part == "" {
+			return nil, fmt.Errorf("invalid gem version: %q", v)
+		}
+		start := 0
+		for i := 1; i <= len(part); i++ {
+			if i == len(part) || isDigit(part[i]) != isDigit(part[start]) {
+				segments = append(segments, part[start:i])
+				start = i
+			}
+		}
+	}
+	for _, segment := range segments {
+		for i := 0; i < len(segment); i++ {
+			if c := segment[i]; !isDigit(c) && !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z') {
+				return nil, fmt.Errorf("invalid gem version: %q", v)
+			}
+		}
+	}
+	return segments, nil
+}
+
+func compareGemVersions(a, b string) (int, error) {
+	sa, err := gemSegments(a)
+	if err != nil {
+		return 0, err
+	}
+	sb, err := gemSegments(b)
+	if err != nil {
+		return 0, err
+	}
+	// the shorter version is padded with zeros, so that "1.0" equals "1",
+	// while "1.a" is lower than "1", as letters mark a prerelease.
+	for i := 0; i < len(sa) || i < len(sb); i++ {
+		x, y := "0", "0"
+		if i < len(sa) {
+			x = sa[i]
+		}
+		if i < len(sb) {
+			y = sb[i]
+		}
+		var c int
+		switch xNum, yNum := isNumeric(x), isNumeric(y); {
+		case xNum && yNum:
+			c = compareNumeric(x, y)
+		case xNum:
+			c = 1
+		case yNum:
+			c = -1
+		default:
+			c = strings.Compare(x, y)
+		}
+		if c != 0 {
+			return c, nil
+		}
+	}
+	return 0, nil
+}
+
+// pep440Key holds the parts of a PEP 440 version in the order in which they
+// are compared. Missing pre-release, post-release and development release
+// segments are given ranks which sort them as PEP 440 specifies.
+type pep440Key struct {
+	epoch   string
+	release []int
+	preRank int
+	pre     string
+	post    string
+	dev     string
+	hasDev  bool
+}
+
+// pep440PreRanks orders the normalized pre-release labels of PEP 440.
+var pep440PreRanks = map[string]int{"a": 1, "b": 2, "rc": 3}
+
+func parsePEP440(v string) (pep440Key, error) {
+	release, pre, post, dev, err := PackageURL{Type: TypePyPi, Version: v}.PyPIVersionParts()
+	if err != nil {
+		return pep440Key{}, err
+	}
+	k := pep440Key{epoch: "0", release: release, post: "-1"}
+	if e, _, ok := strings.Cut(strings.TrimPrefix(strings.TrimSpace(v), "v"), "!"); ok {
+		k.epoch = e
+	}
+	switch {
+	case pre != "":
+		label := strings.TrimRight(pre, "0123456789")
+		k.preRank, k.pre = pep440PreRanks[label], pre[len(label):]
+	case post == "" && dev != "":
+		// a development release of the final version, such as "1.0.dev0",
+		// comes before its pre-releases.
+		k.preRank = 0
+	default:
+		k.preRank = len(pep440PreRanks) + 1
+	}
+	if post != "" {
+		k.post = strings.TrimPrefix(post, "post")
+	}
+	if dev != "" {
+		k.dev, k.hasDev = strings.TrimPrefix(dev, "dev"), true
+	}
+	return k, nil
+}
+
+func comparePEP440(a, b string) (int, error) {
+	ka, err := parsePEP440(a)
+	if err != nil {
+		return 0, err
+	}
+	kb, err := parsePEP440(b)
+	if err != nil {
+		return 0, err
+	}
+	if c := compareNumeric(ka.epoch, kb.epoch); c != 0 {
+		return c, nil
+	}
+	// trailing zeros of the release are insignificant: "1.0" equals "1".
+	for i := 0; i < len(ka.release) || i < len(kb.release); i++ {
+		var x, y int
+		if i < len(ka.release) {
+			x = ka.release[i]
+		}
+		if i < len(kb.release) {
+			y = kb.release[i]
+		}
+		if c := compareInts(x, y); c != 0 {
+			return c, nil
+		}
+	}
+	if c := compareInts(ka.preRank, kb.preRank); c != 0 {
+		return c, nil
+	}
+	if c := compareNumeric(ka.pre, kb.pre); c != 0 {
+		return c, nil
+	}
+	if c := compareSigned(ka.post, kb.post); c != 0 {
+		return c, nil
+	}
+	// a version without a development release is higher than any of its
+	// development releases.
+	switch {
+	case ka.hasDev && kb.hasDev:
+		return compareNumeric(ka.dev, kb.dev), nil
+	case ka.hasDev:
+		return -1, nil
+	case kb.hasDev:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// compareSigned compares two numbers which are either a string of digits or
+// "-1", which sorts before any of them.
+func compareSigned(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "-1":
+		return -1
+	case b == "-1":
+		return 1
+	}
+	return compareNumeric(a, b)
+}
+
+// compareNumeric compares two non-negative integers given as strings of
+// digits, which may be arbitrarily large.
+func compareNumeric(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if c := compareInts(len(a), len(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// isNumeric reports whether s is a non-empty string of ASCII digits.
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
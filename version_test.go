@@ -0,0 +1,94 @@
+/*
+Copyright (c) the purl authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package packageurl_test
+
+import (
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+func TestVersionCompare(t *testing.T) {
+	testCases := []struct {
+		typ  string
+		a, b string
+		want int
+	}{
+		{typ: "npm", a: "1.2.3", b: "1.2.3", want: 0},
+		{typ: "npm", a: "1.2.3", b: "1.10.0", want: -1},
+		{typ: "npm", a: "2.0.0", b: "1.99.99", want: 1},
+		{typ: "npm", a: "1.0.0-alpha", b: "1.0.0", want: -1},
+		{typ: "npm", a: "1.0.0-alpha", b: "1.0.0-alpha.1", want: -1},
+		{typ: "npm", a: "1.0.0-alpha.1", b: "1.0.0-alpha.beta", want: -1},
+		{typ: "npm", a: "1.0.0-beta.2", b: "1.0.0-beta.11", want: -1},
+		{typ: "npm", a: "1.0.0-rc.1", b: "1.0.0-beta.11", want: 1},
+		{typ: "npm", a: "1.0.0+build.1", b: "1.0.0+build.2", want: 0},
+		{typ: "cargo", a: "0.9.1", b: "0.10.0", want: -1},
+		{typ: "golang", a: "v1.2.0", b: "v1.2.0-20230101000000-abcdef123456", want: 1},
+		{typ: "gem", a: "1.0", b: "1.0.0", want: 0},
+		{typ: "gem", a: "1.0.0.rc1", b: "1.0.0", want: -1},
+		{typ: "gem", a: "1.0.0.rc1", b: "1.0.0.beta2", want: 1},
+		{typ: "gem", a: "1.10", b: "1.9", want: 1},
+		{typ: "pypi", a: "1.0", b: "1.0.0", want: 0},
+		{typ: "pypi", a: "1.0.dev0", b: "1.0a1", want: -1},
+		{typ: "pypi", a: "1.0rc1", b: "1.0", want: -1},
+		{typ: "pypi", a: "1.0.post1", b: "1.0", want: 1},
+		{typ: "pypi", a: "1.0.post1.dev1", b: "1.0.post1", want: -1},
+		{typ: "pypi", a: "1!0.1", b: "2.0", want: 1},
+	}
+	for _, testCase := range testCases {
+		a := packageurl.PackageURL{Type: testCase.typ, Name: "foo", Version: testCase.a}
+		b := packageurl.PackageURL{Type: testCase.typ, Name: "foo", Version: testCase.b}
+		got, err := a.VersionCompare(b)
+		if err != nil {
+			t.Errorf("VersionCompare(%s %q, %q): unexpected error: %v", testCase.typ, testCase.a, testCase.b, err)
+			continue
+		}
+		if got != testCase.want {
+			t.Errorf("VersionCompare(%s %q, %q): want %d, got %d", testCase.typ, testCase.a, testCase.b, testCase.want, got)
+		}
+		if got, _ := b.VersionCompare(a); got != -testCase.want {
+			t.Errorf("VersionCompare(%s %q, %q): want %d, got %d", testCase.typ, testCase.b, testCase.a, -testCase.want, got)
+		}
+	}
+}
+
+func TestVersionCompareErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		a, b string
+	}{
+		{name: "different types", a: "pkg:npm/foo@1.0.0", b: "pkg:pypi/foo@1.0.0"},
+		{name: "undefined ordering", a: "pkg:deb/debian/curl@7.50.3-1", b: "pkg:deb/debian/curl@7.50.3-2"},
+		{name: "missing version", a: "pkg:npm/foo@1.0.0", b: "pkg:npm/foo"},
+		{name: "invalid semver", a: "pkg:npm/foo@1.0.0", b: "pkg:npm/foo@latest"},
+		{name: "invalid PEP 440 version", a: "pkg:pypi/foo@1.0.0", b: "pkg:pypi/foo@1.0.0-foo"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			a, b := packageurl.MustParse(testCase.a), packageurl.MustParse(testCase.b)
+			if c, err := a.VersionCompare(b); err == nil {
+				t.Errorf("VersionCompare(%q, %q): want error, got %d", testCase.a, testCase.b, c)
+			}
+		})
+	}
+}